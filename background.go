@@ -0,0 +1,31 @@
+package quinarymcgraphics
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+)
+
+// NewCheckerboard returns a width x height image of alternating light and
+// dark gray squares with sides of size pixels, the usual way of showing
+// transparent areas. A size below 1 is treated as 1. Like GenerateGraphic, it
+// returns ErrInvalidSize unless width and height are positive.
+func NewCheckerboard(width, height, size int) (*image.RGBA, error) {
+	if err := ValidateSize(width, height); err != nil {
+		return nil, err
+	}
+	size = max(size, 1)
+	light := image.NewUniform(color.RGBA{0xcc, 0xcc, 0xcc, 0xff})
+	dark := image.NewUniform(color.RGBA{0x99, 0x99, 0x99, 0xff})
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y += size {
+		for x := 0; x < width; x += size {
+			src := light
+			if (x/size+y/size)%2 == 1 {
+				src = dark
+			}
+			draw.Draw(img, image.Rect(x, y, x+size, y+size), src, image.Point{}, draw.Src)
+		}
+	}
+	return img, nil
+}
@@ -0,0 +1,77 @@
+package quinarymcgraphics
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+)
+
+func TestNewCheckerboard(t *testing.T) {
+	tests := []struct {
+		name                string
+		width, height, size int
+		wantErr             error
+	}{
+		{"valid", 40, 30, 10, nil},
+		{"zero size", 4, 4, 0, nil},
+		{"negative size", 4, 4, -3, nil},
+		{"zero width", 0, 4, 2, ErrInvalidSize},
+		{"negative height", 4, -1, 2, ErrInvalidSize},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img, err := NewCheckerboard(tt.width, tt.height, tt.size)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewCheckerboard error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if b := img.Bounds(); b.Dx() != tt.width || b.Dy() != tt.height {
+				t.Errorf("bounds = %v, want %dx%d", b, tt.width, tt.height)
+			}
+		})
+	}
+}
+
+func TestNewCheckerboardAlternates(t *testing.T) {
+	img, err := NewCheckerboard(4, 4, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if img.At(0, 0) == img.At(1, 0) || img.At(0, 0) != img.At(1, 1) {
+		t.Errorf("squares do not alternate: %v %v %v", img.At(0, 0), img.At(1, 0), img.At(1, 1))
+	}
+}
+
+func TestGenerateGraphicBackground(t *testing.T) {
+	bg := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	blue := color.RGBA{0x00, 0x00, 0xff, 0xff}
+	draw.Draw(bg, bg.Bounds(), image.NewUniform(blue), image.Point{}, draw.Src)
+
+	// Off cells hold a circle, so the cell corners have no shape.
+	mc := uniformChain(t, Off)
+	mc.Palette = Palette{Off: color.White}
+	mc.Background = bg
+	dc, err := mc.GenerateGraphic(2*CellSize, CellSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	img := dc.Image()
+	if got := img.At(1, 1); !sameColor(got, blue) {
+		t.Errorf("corner without a shape is %v, want background %v", got, blue)
+	}
+	if got := img.At(CellSize+CellSize/2, CellSize/2); !sameColor(got, color.White) {
+		t.Errorf("circle center is %v, want palette color %v", got, color.White)
+	}
+}
+
+func TestGenerateGraphicEmptyBackground(t *testing.T) {
+	mc := uniformChain(t, On)
+	mc.Background = image.NewRGBA(image.Rectangle{})
+	if _, err := mc.GenerateGraphic(CellSize, CellSize); !errors.Is(err, ErrEmptyBackground) {
+		t.Errorf("GenerateGraphic error = %v, want %v", err, ErrEmptyBackground)
+	}
+}
@@ -0,0 +1,216 @@
+// Command quinarymcgraphics renders a generative graphic from a Markov chain
+// over quinary logic states and saves it as a PNG.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"image"
+	"io"
+	"log/slog"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	qmc "github.com/ccianos/quinarymcgraphics"
+	"github.com/fogleman/gg"
+)
+
+func main() {
+	seed := flag.Int64("seed", 0, "random seed (0 picks one from the current time)")
+	width := flag.Int("width", 400, "graphic width in pixels")
+	height := flag.Int("height", 400, "graphic height in pixels")
+	var logLevel slog.Level
+	flag.TextVar(&logLevel, "log-level", slog.LevelInfo, "minimum log level (debug, info, warn, error)")
+	logFormat := flag.String("log-format", "text", "log output format (text or json)")
+	paletteName := flag.String("palette", "default", "shape color palette: "+strings.Join(qmc.PaletteNames(), ", "))
+	palettePreview := flag.Bool("palette-preview", false, "print a swatch of every palette and exit")
+	background := flag.String("background", "", `image file to draw beneath the shapes, or "checkerboard" (default transparent)`)
+	dryRun := flag.Bool("dry-run", false, "print the resolved run plan and exit without generating anything")
+	outDir := flag.String("out-dir", "", "write each run into a new timestamped subdirectory of this directory")
+	flag.Parse()
+
+	opts := &slog.HandlerOptions{Level: logLevel}
+	switch *logFormat {
+	case "text":
+		slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, opts)))
+	case "json":
+		slog.SetDefault(slog.New(slog.NewJSONHandler(os.Stderr, opts)))
+	default:
+		slog.Error("unknown log format", "format", *logFormat)
+		os.Exit(2)
+	}
+
+	if *palettePreview {
+		printPalettes(os.Stdout)
+		return
+	}
+	palette, ok := qmc.Palettes[*paletteName]
+	if !ok {
+		slog.Error("unknown palette", "palette", *paletteName)
+		os.Exit(2)
+	}
+
+	runStart := time.Now()
+	if *seed == 0 {
+		*seed = runStart.UnixNano()
+	}
+
+	// Define transition matrix
+	transitionMatrix := [][]qmc.QuinaryLogic{
+		{qmc.On, qmc.On, qmc.OffWithinOn},
+		{qmc.On, qmc.On, qmc.OnWithinOff},
+		{qmc.OffWithinOn, qmc.OnWithinOff, qmc.Neutral},
+	}
+
+	// Create Markov chain
+	mc, err := qmc.NewMarkovChain(transitionMatrix, rand.NewSource(*seed))
+	if err != nil {
+		slog.Error("creating Markov chain", "err", err)
+		os.Exit(1)
+	}
+	mc.Palette = palette
+	mc.Logger = slog.Default()
+	if err := qmc.ValidateSize(*width, *height); err != nil {
+		slog.Error("checking graphic size", "err", err)
+		os.Exit(2)
+	}
+
+	if *dryRun {
+		bgBytes, err := backgroundBytes(*background, *width, *height)
+		if err != nil {
+			slog.Error("loading background", "path", *background, "err", err)
+			os.Exit(1)
+		}
+		printPlan(os.Stdout, transitionMatrix, *width, *height, *paletteName, *background, bgBytes, *seed, *outDir, runStart)
+		return
+	}
+
+	switch *background {
+	case "":
+	case "checkerboard":
+		mc.Background, err = qmc.NewCheckerboard(*width, *height, qmc.CellSize/5)
+		if err != nil {
+			slog.Error("creating checkerboard", "err", err)
+			os.Exit(1)
+		}
+	default:
+		img, err := gg.LoadImage(*background)
+		if err != nil {
+			slog.Error("loading background", "path", *background, "err", err)
+			os.Exit(1)
+		}
+		mc.Background = img
+	}
+
+	// Generate graphic
+	slog.Debug("generating graphic", "width", *width, "height", *height, "seed", *seed)
+	start := time.Now()
+	dc, err := mc.GenerateGraphic(*width, *height)
+	if err != nil {
+		slog.Error("generating graphic", "err", err)
+		os.Exit(1)
+	}
+	slog.Debug("graphic generated", "elapsed", time.Since(start))
+
+	// Save graphic to file
+	dir := "."
+	if *outDir != "" {
+		dir, err = createRunDir(*outDir, runStart, *seed)
+		if err != nil {
+			slog.Error("creating run directory", "dir", *outDir, "err", err)
+			os.Exit(1)
+		}
+	}
+	f, err := createOutput(dir, "output.png")
+	if err != nil {
+		slog.Error("creating output file", "dir", dir, "err", err)
+		os.Exit(1)
+	}
+	if err := encodePNG(f, dc.Image(), "Seed", strconv.FormatInt(*seed, 10)); err != nil {
+		f.Close()
+		slog.Error("saving graphic", "path", f.Name(), "err", err)
+		os.Exit(1)
+	}
+	if err := f.Close(); err != nil {
+		slog.Error("saving graphic", "path", f.Name(), "err", err)
+		os.Exit(1)
+	}
+	if *outDir != "" {
+		if err := updateLatest(*outDir, filepath.Base(dir)); err != nil {
+			slog.Warn("updating latest symlink", "dir", *outDir, "err", err)
+		}
+	}
+	slog.Info("graphic saved", "path", f.Name(), "seed", *seed)
+}
+
+// backgroundBytes returns roughly how much memory the -background option would
+// take for a width x height graphic. An image file is checked by reading only
+// its header, not decoded.
+func backgroundBytes(background string, width, height int) (int, error) {
+	switch background {
+	case "":
+		return 0, nil
+	case "checkerboard":
+		return width * height * 4, nil
+	}
+	f, err := os.Open(background)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	cfg, _, err := image.DecodeConfig(f)
+	if err != nil {
+		return 0, err
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 {
+		return 0, qmc.ErrEmptyBackground
+	}
+	return cfg.Width * cfg.Height * 4, nil
+}
+
+// printPlan writes the resolved settings for a run to w: what would be
+// generated, where it would be saved, and roughly how much memory the image
+// and background buffers would take.
+func printPlan(w io.Writer, transitionMatrix [][]qmc.QuinaryLogic, width, height int, paletteName, background string, bgBytes int, seed int64, outDir string, start time.Time) {
+	cols := (width + qmc.CellSize - 1) / qmc.CellSize
+	rows := (height + qmc.CellSize - 1) / qmc.CellSize
+	fmt.Fprintf(w, "size:    %dx%d px\n", width, height)
+	fmt.Fprintf(w, "cells:   %dx%d (%d cells of %dpx)\n", cols, rows, cols*rows, qmc.CellSize)
+	fmt.Fprintf(w, "palette: %s\n", paletteName)
+	if background != "" {
+		fmt.Fprintf(w, "bg:      %s\n", background)
+	}
+	fmt.Fprintf(w, "seed:    %d\n", seed)
+	fmt.Fprintf(w, "states:  %d\n", len(transitionMatrix))
+	for i, row := range transitionMatrix {
+		fmt.Fprintf(w, "  %d: %v\n", i, row)
+	}
+	if outDir != "" {
+		fmt.Fprintf(w, "output:  %s\n", filepath.Join(outDir, freeName(outDir, runDirName(start, seed)), "output.png"))
+	} else {
+		fmt.Fprintf(w, "output:  %s\n", freeName(".", "output.png"))
+	}
+	imgBytes := width * height * 4
+	if bgBytes > 0 {
+		fmt.Fprintf(w, "memory:  ~%d KiB (%d KiB image, %d KiB background)\n", (imgBytes+bgBytes)/1024, imgBytes/1024, bgBytes/1024)
+	} else {
+		fmt.Fprintf(w, "memory:  ~%d KiB image buffer\n", imgBytes/1024)
+	}
+}
+
+// printPalettes writes one line per palette preset to w, showing each state's
+// color as a 24-bit ANSI color swatch.
+func printPalettes(w io.Writer) {
+	for _, name := range qmc.PaletteNames() {
+		fmt.Fprintf(w, "%-10s", name)
+		for _, c := range qmc.Palettes[name] {
+			r, g, b, _ := c.RGBA()
+			fmt.Fprintf(w, " \x1b[48;2;%d;%d;%dm    \x1b[0m", r>>8, g>>8, b>>8)
+		}
+		fmt.Fprintln(w)
+	}
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"errors"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBackgroundBytes(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "bg.png")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := png.Encode(f, image.NewRGBA(image.Rect(0, 0, 16, 8))); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	tests := []struct {
+		background string
+		want       int
+	}{
+		{"", 0},
+		{"checkerboard", 40 * 30 * 4},
+		{path, 16 * 8 * 4},
+	}
+	for _, tt := range tests {
+		got, err := backgroundBytes(tt.background, 40, 30)
+		if err != nil {
+			t.Errorf("backgroundBytes(%q): %v", tt.background, err)
+		} else if got != tt.want {
+			t.Errorf("backgroundBytes(%q) = %d, want %d", tt.background, got, tt.want)
+		}
+	}
+	if _, err := backgroundBytes(filepath.Join(dir, "missing.png"), 40, 30); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("missing file error = %v, want %v", err, os.ErrNotExist)
+	}
+}
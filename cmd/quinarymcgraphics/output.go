@@ -0,0 +1,120 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"image"
+	"image/png"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// candidate returns the i-th name tried for name: name itself for i == 0,
+// otherwise name with "-i" inserted before its extension.
+func candidate(name string, i int) string {
+	if i == 0 {
+		return name
+	}
+	ext := filepath.Ext(name)
+	return fmt.Sprintf("%s-%d%s", strings.TrimSuffix(name, ext), i, ext)
+}
+
+// createOutput creates a new file named name in dir for writing. If name is
+// already taken, a numeric suffix is added before the extension instead of
+// overwriting the existing file.
+func createOutput(dir, name string) (*os.File, error) {
+	for i := 0; ; i++ {
+		f, err := os.OpenFile(filepath.Join(dir, candidate(name, i)), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if errors.Is(err, fs.ErrExist) {
+			continue
+		}
+		return f, err
+	}
+}
+
+// freeName returns the first candidate for name that does not exist in dir
+// yet, without creating it. createOutput and createRunDir pick the same name
+// unless something else claims it first.
+func freeName(dir, name string) string {
+	for i := 0; ; i++ {
+		c := candidate(name, i)
+		if _, err := os.Lstat(filepath.Join(dir, c)); err != nil {
+			return c
+		}
+	}
+}
+
+// runDirName returns the name of the subdirectory for a run started at start
+// with the given seed, before any collision suffix.
+func runDirName(start time.Time, seed int64) string {
+	return fmt.Sprintf("%s-seed%d", start.Format("20060102-150405"), seed)
+}
+
+// createRunDir creates a fresh subdirectory of outDir for a single run, named
+// by runDirName with a numeric suffix if that name is already taken.
+func createRunDir(outDir string, start time.Time, seed int64) (string, error) {
+	if err := os.MkdirAll(outDir, 0o755); err != nil {
+		return "", err
+	}
+	name := runDirName(start, seed)
+	for i := 0; ; i++ {
+		dir := filepath.Join(outDir, candidate(name, i))
+		err := os.Mkdir(dir, 0o755)
+		if errors.Is(err, fs.ErrExist) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		return dir, nil
+	}
+}
+
+// updateLatest replaces the outDir/latest symlink with one pointing at target,
+// which is relative to outDir. If something other than a symlink is already
+// at that path it is left in place and an error is returned.
+func updateLatest(outDir, target string) error {
+	link := filepath.Join(outDir, "latest")
+	if fi, err := os.Lstat(link); err == nil {
+		if fi.Mode()&fs.ModeSymlink == 0 {
+			return fmt.Errorf("%s exists and is not a symlink", link)
+		}
+		if err := os.Remove(link); err != nil {
+			return err
+		}
+	}
+	return os.Symlink(target, link)
+}
+
+// encodePNG writes img to w as a PNG carrying a tEXt chunk that maps key to
+// value, so run metadata such as the seed travels with the image.
+func encodePNG(w io.Writer, img image.Image, key, value string) error {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return err
+	}
+	// The signature and IHDR chunk always come first; ancillary chunks may
+	// follow directly after them.
+	const ihdrEnd = 8 + 4 + 4 + 13 + 4
+	b := buf.Bytes()
+
+	data := append([]byte(key+"\x00"), value...)
+	chunk := binary.BigEndian.AppendUint32(nil, uint32(len(data)))
+	chunk = append(chunk, "tEXt"...)
+	chunk = append(chunk, data...)
+	chunk = binary.BigEndian.AppendUint32(chunk, crc32.ChecksumIEEE(chunk[4:]))
+
+	for _, part := range [][]byte{b[:ihdrEnd], chunk, b[ihdrEnd:]} {
+		if _, err := w.Write(part); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCreateOutputAddsSuffix(t *testing.T) {
+	dir := t.TempDir()
+	for _, want := range []string{"output.png", "output-1.png", "output-2.png"} {
+		f, err := createOutput(dir, "output.png")
+		if err != nil {
+			t.Fatal(err)
+		}
+		f.Close()
+		if got := filepath.Base(f.Name()); got != want {
+			t.Errorf("createOutput = %s, want %s", got, want)
+		}
+	}
+}
+
+func TestCreateRunDirAddsSuffix(t *testing.T) {
+	outDir := filepath.Join(t.TempDir(), "runs")
+	start := time.Date(2024, 3, 1, 12, 30, 45, 0, time.UTC)
+	for _, want := range []string{"20240301-123045-seed7", "20240301-123045-seed7-1"} {
+		dir, err := createRunDir(outDir, start, 7)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := filepath.Base(dir); got != want {
+			t.Errorf("createRunDir = %s, want %s", got, want)
+		}
+		if fi, err := os.Stat(dir); err != nil || !fi.IsDir() {
+			t.Errorf("%s is not a directory: %v", dir, err)
+		}
+	}
+}
+
+func TestUpdateLatest(t *testing.T) {
+	outDir := t.TempDir()
+	for _, target := range []string{"run-a", "run-b"} {
+		if err := updateLatest(outDir, target); err != nil {
+			t.Fatal(err)
+		}
+		got, err := os.Readlink(filepath.Join(outDir, "latest"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != target {
+			t.Errorf("latest -> %s, want %s", got, target)
+		}
+	}
+}
+
+func TestUpdateLatestKeepsRegularFile(t *testing.T) {
+	outDir := t.TempDir()
+	link := filepath.Join(outDir, "latest")
+	if err := os.WriteFile(link, []byte("keep"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := updateLatest(outDir, "run-a"); err == nil {
+		t.Error("updateLatest over a regular file succeeded, want error")
+	}
+	if b, err := os.ReadFile(link); err != nil || string(b) != "keep" {
+		t.Errorf("latest = %q, %v; want the original file", b, err)
+	}
+}
+
+func TestFreeNameMatchesCreateOutput(t *testing.T) {
+	dir := t.TempDir()
+	for i := 0; i < 3; i++ {
+		want := freeName(dir, "output.png")
+		f, err := createOutput(dir, "output.png")
+		if err != nil {
+			t.Fatal(err)
+		}
+		f.Close()
+		if got := filepath.Base(f.Name()); got != want {
+			t.Errorf("freeName = %s, createOutput created %s", want, got)
+		}
+	}
+}
+
+// pngText returns the key/value pairs of the tEXt chunks in the PNG data b.
+func pngText(t *testing.T, b []byte) map[string]string {
+	t.Helper()
+	text := map[string]string{}
+	for b = b[8:]; len(b) >= 12; {
+		n := binary.BigEndian.Uint32(b)
+		typ, data := string(b[4:8]), b[8:8+n]
+		if typ == "tEXt" {
+			key, value, _ := bytes.Cut(data, []byte{0})
+			text[string(key)] = string(value)
+		}
+		b = b[12+n:]
+	}
+	return text
+}
+
+func TestEncodePNGStoresText(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 3, 2))
+	var buf bytes.Buffer
+	if err := encodePNG(&buf, img, "Seed", "42"); err != nil {
+		t.Fatal(err)
+	}
+	// png.Decode verifies every chunk CRC, including the inserted one.
+	got, err := png.Decode(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Bounds() != img.Bounds() {
+		t.Errorf("bounds = %v, want %v", got.Bounds(), img.Bounds())
+	}
+	if seed := pngText(t, buf.Bytes())["Seed"]; seed != "42" {
+		t.Errorf("Seed = %q, want %q", seed, "42")
+	}
+}
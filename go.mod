@@ -2,8 +2,9 @@ module github.com/ccianos/quinarymcgraphics
 
 go 1.22.1
 
+require github.com/fogleman/gg v1.3.0
+
 require (
-	github.com/fogleman/gg v1.3.0 // indirect
 	github.com/golang/freetype v0.0.0-20170609003504-e2365dfdc4a0 // indirect
 	golang.org/x/image v0.15.0 // indirect
 )
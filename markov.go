@@ -0,0 +1,179 @@
+/*
+This code demonstrates the implementation of a simple generative art system using Markov chains and quinary logic.
+
+Generative art involves creating artwork using algorithms, often resulting in unpredictable and unique outcomes.
+Markov chains are mathematical models that describe a sequence of events where the probability of each event
+depends only on the state attained in the previous event. Quinary logic, a system with five possible states,
+is used here to introduce variability into the generated artwork.
+
+In this implementation, a Markov chain is utilized to determine the transitions between different states of the quinary logic system,
+representing various visual elements or properties in the artwork. Each state corresponds to a different artistic element,
+such as color, shape, or texture. By iterating through the Markov chain, the program generates a sequence of states,
+which are then interpreted to produce the final artwork.
+
+Through the combination of Markov chains and quinary logic, this code exemplifies how algorithmic techniques can be applied to
+create visually captivating and dynamic generative art. The resulting artwork reflects the inherent complexity and richness that emerge
+from the interaction of simple rules and randomness.
+*/
+package quinarymcgraphics
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"log/slog"
+	"math/rand"
+
+	"github.com/fogleman/gg"
+)
+
+// QuinaryLogic represents the five states of our quinary logic system.
+type QuinaryLogic int
+
+const (
+	On QuinaryLogic = iota
+	Off
+	OnWithinOff
+	OffWithinOn
+	Neutral
+)
+
+// String returns the name of the state.
+func (q QuinaryLogic) String() string {
+	switch q {
+	case On:
+		return "On"
+	case Off:
+		return "Off"
+	case OnWithinOff:
+		return "OnWithinOff"
+	case OffWithinOn:
+		return "OffWithinOn"
+	case Neutral:
+		return "Neutral"
+	}
+	return fmt.Sprintf("QuinaryLogic(%d)", int(q))
+}
+
+// CellSize is the side length in pixels of the square cell drawn for each
+// state of the chain.
+const CellSize = 50
+
+// Errors returned when a chain or graphic cannot be built.
+var (
+	ErrEmptyMatrix     = errors.New("quinarymcgraphics: transition matrix has no states")
+	ErrRowLength       = errors.New("quinarymcgraphics: transition row must have between 3 and len(matrix) entries")
+	ErrInvalidState    = errors.New("quinarymcgraphics: value is not a quinary logic state")
+	ErrInvalidSize     = errors.New("quinarymcgraphics: graphic width and height must be positive")
+	ErrEmptyBackground = errors.New("quinarymcgraphics: background image is empty")
+)
+
+// MarkovChain represents a simple Markov chain graphic generator.
+type MarkovChain struct {
+	// Palette colors the shapes. It is DefaultPalette unless changed.
+	Palette Palette
+	// Background, if non-nil, is scaled to fill the graphic and drawn beneath
+	// the shapes. Otherwise cells without a shape are left transparent.
+	Background image.Image
+	// Logger, if non-nil, receives a debug record for every cell transition.
+	Logger *slog.Logger
+
+	transitionMatrix [][]QuinaryLogic
+	rng              *rand.Rand
+}
+
+// NewMarkovChain creates a new Markov chain with the given transition matrix.
+// All random choices are drawn from src, so the same source seed always
+// produces the same graphic.
+//
+// Each row is read as a state: its first three entries pick the shape drawn,
+// and the next state is one of the row's indices, so every row needs at least
+// three entries and no more entries than the matrix has rows.
+func NewMarkovChain(transitionMatrix [][]QuinaryLogic, src rand.Source) (*MarkovChain, error) {
+	if len(transitionMatrix) == 0 {
+		return nil, ErrEmptyMatrix
+	}
+	for i, row := range transitionMatrix {
+		if len(row) < 3 || len(row) > len(transitionMatrix) {
+			return nil, fmt.Errorf("row %d: %w", i, ErrRowLength)
+		}
+		for j, v := range row {
+			if v < On || v > Neutral {
+				return nil, fmt.Errorf("row %d, column %d: %w", i, j, ErrInvalidState)
+			}
+		}
+	}
+	return &MarkovChain{
+		Palette:          DefaultPalette,
+		transitionMatrix: transitionMatrix,
+		rng:              rand.New(src),
+	}, nil
+}
+
+// ValidateSize returns ErrInvalidSize unless width and height are both
+// positive.
+func ValidateSize(width, height int) error {
+	if width <= 0 || height <= 0 {
+		return ErrInvalidSize
+	}
+	return nil
+}
+
+// GenerateGraphic generates a graphic using the Markov chain.
+func (mc *MarkovChain) GenerateGraphic(width, height int) (*gg.Context, error) {
+	if err := ValidateSize(width, height); err != nil {
+		return nil, err
+	}
+	dc := gg.NewContext(width, height)
+	if mc.Background != nil {
+		b := mc.Background.Bounds()
+		if b.Empty() {
+			return nil, ErrEmptyBackground
+		}
+		dc.Push()
+		dc.Scale(float64(width)/float64(b.Dx()), float64(height)/float64(b.Dy()))
+		dc.DrawImage(mc.Background, -b.Min.X, -b.Min.Y)
+		dc.Pop()
+	}
+	currentState := mc.rng.Intn(len(mc.transitionMatrix))
+
+	for y := 0; y < height; y += CellSize {
+		for x := 0; x < width; x += CellSize {
+			mc.drawShape(dc, x, y, currentState)
+			nextState := mc.getNextState(currentState)
+			if mc.Logger != nil {
+				mc.Logger.Debug("transition", "x", x, "y", y, "state", currentState, "next", nextState)
+			}
+			currentState = nextState
+		}
+	}
+
+	return dc, nil
+}
+
+// drawShape draws a shape based on the current state.
+func (mc *MarkovChain) drawShape(dc *gg.Context, x, y, currentState int) {
+	switch {
+	case mc.transitionMatrix[currentState][0] == On && mc.transitionMatrix[currentState][1] == On && mc.transitionMatrix[currentState][2] == OffWithinOn:
+		dc.DrawRectangle(float64(x), float64(y), CellSize, CellSize)
+		dc.SetColor(mc.Palette.color(On))
+	case mc.transitionMatrix[currentState][0] == Off && mc.transitionMatrix[currentState][1] == Off && mc.transitionMatrix[currentState][2] == OnWithinOff:
+		dc.DrawCircle(float64(x)+CellSize/2, float64(y)+CellSize/2, CellSize/2)
+		dc.SetColor(mc.Palette.color(Off))
+	case mc.transitionMatrix[currentState][0] == OnWithinOff && mc.transitionMatrix[currentState][1] == OnWithinOff && mc.transitionMatrix[currentState][2] == Neutral:
+		dc.DrawRectangle(float64(x), float64(y), CellSize, CellSize)
+		dc.SetColor(mc.Palette.color(OnWithinOff))
+	case mc.transitionMatrix[currentState][0] == OffWithinOn && mc.transitionMatrix[currentState][1] == OffWithinOn && mc.transitionMatrix[currentState][2] == On:
+		dc.DrawCircle(float64(x)+CellSize/2, float64(y)+CellSize/2, CellSize/2)
+		dc.SetColor(mc.Palette.color(OffWithinOn))
+	case mc.transitionMatrix[currentState][0] == Neutral && mc.transitionMatrix[currentState][1] == Neutral && mc.transitionMatrix[currentState][2] == Neutral:
+		dc.DrawRectangle(float64(x), float64(y), CellSize, CellSize)
+		dc.SetColor(mc.Palette.color(Neutral))
+	}
+	dc.Fill()
+}
+
+// getNextState selects the next state based on the current state and transition probabilities.
+func (mc *MarkovChain) getNextState(currentState int) int {
+	return mc.rng.Intn(len(mc.transitionMatrix[currentState]))
+}
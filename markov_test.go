@@ -0,0 +1,247 @@
+package quinarymcgraphics
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"image"
+	"image/color"
+	"log/slog"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+var testMatrix = [][]QuinaryLogic{
+	{On, On, OffWithinOn},
+	{On, On, OnWithinOff},
+	{OffWithinOn, OnWithinOff, Neutral},
+}
+
+// generate builds a chain over testMatrix seeded with seed and returns the
+// pixels of a 400x400 graphic.
+func generate(t *testing.T, seed int64) *image.RGBA {
+	t.Helper()
+	mc, err := NewMarkovChain(testMatrix, rand.NewSource(seed))
+	if err != nil {
+		t.Fatal(err)
+	}
+	dc, err := mc.GenerateGraphic(400, 400)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dc.Image().(*image.RGBA)
+}
+
+func TestGenerateGraphicSameSeed(t *testing.T) {
+	a, b := generate(t, 42), generate(t, 42)
+	if !bytes.Equal(a.Pix, b.Pix) {
+		t.Error("two chains seeded with 42 produced different graphics")
+	}
+	if c := generate(t, 43); bytes.Equal(a.Pix, c.Pix) {
+		t.Error("seeds 42 and 43 produced identical graphics")
+	}
+}
+
+// shapeRows gives, for each state, a transition row that draws the shape led
+// by that state.
+var shapeRows = [5][]QuinaryLogic{
+	On:          {On, On, OffWithinOn},
+	Off:         {Off, Off, OnWithinOff},
+	OnWithinOff: {OnWithinOff, OnWithinOff, Neutral},
+	OffWithinOn: {OffWithinOn, OffWithinOn, On},
+	Neutral:     {Neutral, Neutral, Neutral},
+}
+
+// uniformChain returns a chain whose every state draws the shape led by q.
+func uniformChain(t *testing.T, q QuinaryLogic) *MarkovChain {
+	t.Helper()
+	row := shapeRows[q]
+	mc, err := NewMarkovChain([][]QuinaryLogic{row, row, row}, rand.NewSource(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return mc
+}
+
+// sameColor reports whether a and b are equal once converted to RGBA.
+func sameColor(a, b color.Color) bool {
+	return color.RGBAModel.Convert(a) == color.RGBAModel.Convert(b)
+}
+
+func TestGenerateGraphicPalette(t *testing.T) {
+	for _, name := range PaletteNames() {
+		palette := Palettes[name]
+		for q := On; q <= Neutral; q++ {
+			mc := uniformChain(t, q)
+			mc.Palette = palette
+			dc, err := mc.GenerateGraphic(CellSize, CellSize)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := dc.Image().At(CellSize/2, CellSize/2); !sameColor(got, palette[q]) {
+				t.Errorf("%s: %v cell is %v, want %v", name, q, got, palette[q])
+			}
+		}
+	}
+}
+
+func TestGenerateGraphicPartialPalette(t *testing.T) {
+	partial := Palette{Off: color.White}
+	for q := On; q <= Neutral; q++ {
+		mc := uniformChain(t, q)
+		mc.Palette = partial
+		dc, err := mc.GenerateGraphic(CellSize, CellSize)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := DefaultPalette[q]
+		if q == Off {
+			want = color.White
+		}
+		if got := dc.Image().At(CellSize/2, CellSize/2); !sameColor(got, want) {
+			t.Errorf("%v cell is %v, want %v", q, got, want)
+		}
+	}
+}
+
+func TestPaletteNames(t *testing.T) {
+	names := PaletteNames()
+	if !sort.StringsAreSorted(names) {
+		t.Errorf("PaletteNames() = %v, not sorted", names)
+	}
+	if len(names) != len(Palettes) {
+		t.Errorf("PaletteNames() has %d names, Palettes has %d", len(names), len(Palettes))
+	}
+	for _, name := range names {
+		if _, ok := Palettes[name]; !ok {
+			t.Errorf("PaletteNames() includes %q, which is not in Palettes", name)
+		}
+	}
+	for _, name := range []string{"default", "okabe-ito", "cividis", "batlow"} {
+		p, ok := Palettes[name]
+		if !ok {
+			t.Errorf("Palettes has no %q preset", name)
+			continue
+		}
+		for q, c := range p {
+			if c == nil {
+				t.Errorf("%s: %v entry is nil", name, QuinaryLogic(q))
+			}
+		}
+	}
+}
+
+func TestGenerateGraphicLogsTransitions(t *testing.T) {
+	var buf bytes.Buffer
+	mc, err := NewMarkovChain(testMatrix, rand.NewSource(42))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mc.Logger = slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	if _, err := mc.GenerateGraphic(3*CellSize, 2*CellSize); err != nil {
+		t.Fatal(err)
+	}
+
+	dec := json.NewDecoder(&buf)
+	var cells [][2]int
+	for dec.More() {
+		var rec struct {
+			Msg         string
+			X, Y        int
+			State, Next int
+		}
+		if err := dec.Decode(&rec); err != nil {
+			t.Fatal(err)
+		}
+		if rec.Msg != "transition" {
+			t.Errorf("unexpected log record %q", rec.Msg)
+			continue
+		}
+		if rec.Next < 0 || rec.Next >= len(testMatrix) {
+			t.Errorf("next state %d out of range", rec.Next)
+		}
+		cells = append(cells, [2]int{rec.X, rec.Y})
+	}
+	c := CellSize
+	want := [][2]int{{0, 0}, {c, 0}, {2 * c, 0}, {0, c}, {c, c}, {2 * c, c}}
+	if len(cells) != len(want) {
+		t.Fatalf("logged %d transitions %v, want %d", len(cells), cells, len(want))
+	}
+	for i := range want {
+		if cells[i] != want[i] {
+			t.Errorf("transition %d at %v, want %v", i, cells[i], want[i])
+		}
+	}
+}
+
+func TestNewMarkovChain(t *testing.T) {
+	tests := []struct {
+		name    string
+		matrix  [][]QuinaryLogic
+		wantErr error
+	}{
+		{"valid 3x3", testMatrix, nil},
+		{"nil matrix", nil, ErrEmptyMatrix},
+		{"empty matrix", [][]QuinaryLogic{}, ErrEmptyMatrix},
+		{"row too short", [][]QuinaryLogic{
+			{On, On, OffWithinOn},
+			{On, On},
+			{Neutral, Neutral, Neutral},
+		}, ErrRowLength},
+		{"row longer than matrix", [][]QuinaryLogic{
+			{On, On, OffWithinOn, Off},
+			{On, On, OnWithinOff},
+			{Neutral, Neutral, Neutral},
+		}, ErrRowLength},
+		{"state below range", [][]QuinaryLogic{
+			{On, On, OffWithinOn},
+			{On, -1, OnWithinOff},
+			{Neutral, Neutral, Neutral},
+		}, ErrInvalidState},
+		{"state above range", [][]QuinaryLogic{
+			{On, On, OffWithinOn},
+			{On, On, OnWithinOff},
+			{Neutral, Neutral, Neutral + 1},
+		}, ErrInvalidState},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mc, err := NewMarkovChain(tt.matrix, rand.NewSource(1))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewMarkovChain error = %v, want %v", err, tt.wantErr)
+			}
+			if (mc == nil) != (err != nil) {
+				t.Errorf("NewMarkovChain = %v, %v; want exactly one of chain and error", mc, err)
+			}
+		})
+	}
+}
+
+func TestGenerateGraphicSize(t *testing.T) {
+	tests := []struct {
+		name          string
+		width, height int
+		wantErr       error
+	}{
+		{"valid", 120, 80, nil},
+		{"zero width", 0, 80, ErrInvalidSize},
+		{"zero height", 120, 0, ErrInvalidSize},
+		{"negative", -5, -5, ErrInvalidSize},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mc, err := NewMarkovChain(testMatrix, rand.NewSource(1))
+			if err != nil {
+				t.Fatal(err)
+			}
+			dc, err := mc.GenerateGraphic(tt.width, tt.height)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GenerateGraphic error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && (dc.Width() != tt.width || dc.Height() != tt.height) {
+				t.Errorf("graphic is %dx%d, want %dx%d", dc.Width(), dc.Height(), tt.width, tt.height)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package quinarymcgraphics
+
+import (
+	"image/color"
+	"sort"
+)
+
+// Palette holds the fill color for each shape, indexed by the quinary state
+// that leads the matching transition row. Nil entries fall back to
+// DefaultPalette.
+type Palette [5]color.Color
+
+// color returns the fill color for shapes led by state q.
+func (p Palette) color(q QuinaryLogic) color.Color {
+	if p[q] == nil {
+		return DefaultPalette[q]
+	}
+	return p[q]
+}
+
+// DefaultPalette is the original red, blue, green, yellow and black scheme.
+var DefaultPalette = Palette{
+	On:          color.RGBA{0xff, 0x00, 0x00, 0xff},
+	Off:         color.RGBA{0x00, 0x00, 0xff, 0xff},
+	OnWithinOff: color.RGBA{0x00, 0xff, 0x00, 0xff},
+	OffWithinOn: color.RGBA{0xff, 0xff, 0x00, 0xff},
+	Neutral:     color.RGBA{0x00, 0x00, 0x00, 0xff},
+}
+
+// OkabeItoPalette uses the Okabe-Ito colors closest to the default scheme.
+// They stay distinguishable under the common forms of color vision deficiency.
+var OkabeItoPalette = Palette{
+	On:          color.RGBA{0xd5, 0x5e, 0x00, 0xff}, // vermillion
+	Off:         color.RGBA{0x00, 0x72, 0xb2, 0xff}, // blue
+	OnWithinOff: color.RGBA{0x00, 0x9e, 0x73, 0xff}, // bluish green
+	OffWithinOn: color.RGBA{0xf0, 0xe4, 0x42, 0xff}, // yellow
+	Neutral:     color.RGBA{0x00, 0x00, 0x00, 0xff}, // black
+}
+
+// CividisPalette samples the cividis colormap at five evenly spaced points,
+// giving a perceptually uniform ramp from dark blue to yellow.
+var CividisPalette = Palette{
+	On:          color.RGBA{0x00, 0x22, 0x4e, 0xff},
+	Off:         color.RGBA{0x3c, 0x49, 0x6c, 0xff},
+	OnWithinOff: color.RGBA{0x7c, 0x7b, 0x78, 0xff},
+	OffWithinOn: color.RGBA{0xbc, 0xaf, 0x6f, 0xff},
+	Neutral:     color.RGBA{0xfe, 0xe8, 0x38, 0xff},
+}
+
+// BatlowPalette samples Crameri's batlow colormap at five evenly spaced
+// points, a perceptually uniform ramp from dark blue through olive to pink.
+var BatlowPalette = Palette{
+	On:          color.RGBA{0x01, 0x19, 0x59, 0xff},
+	Off:         color.RGBA{0x22, 0x60, 0x61, 0xff},
+	OnWithinOff: color.RGBA{0x82, 0x82, 0x31, 0xff},
+	OffWithinOn: color.RGBA{0xf1, 0x9d, 0x6b, 0xff},
+	Neutral:     color.RGBA{0xfa, 0xcc, 0xfa, 0xff},
+}
+
+// Palettes maps preset names to palettes.
+var Palettes = map[string]Palette{
+	"default":   DefaultPalette,
+	"okabe-ito": OkabeItoPalette,
+	"cividis":   CividisPalette,
+	"batlow":    BatlowPalette,
+}
+
+// PaletteNames returns the names in Palettes in sorted order.
+func PaletteNames() []string {
+	names := make([]string, 0, len(Palettes))
+	for name := range Palettes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}